@@ -1,11 +1,9 @@
 package docs
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"strings"
-	"text/template"
 
 	"github.com/Jeffail/benthos/v3/lib/util/config"
 	"github.com/Jeffail/gabs/v2"
@@ -291,6 +289,62 @@ func iClone(root interface{}) interface{} {
 	return root
 }
 
+// componentRootPath returns the path prefix that a component's root
+// FieldSpec contributes to its children's flattened paths, mirroring how an
+// array- or map-typed field nests its own children in flattenFieldSpecs.
+func componentRootPath(root FieldSpec) string {
+	if root.IsArray {
+		return "[]."
+	} else if root.IsMap {
+		return "<name>."
+	}
+	return ""
+}
+
+// flattenFieldSpecs walks a FieldSpec tree and returns every field (at every
+// depth) with its Name rewritten to the full dotted path from the root, e.g.
+// `foo.bar[].baz`. AsMarkdown and AsJSONSchema both walk this same flattened
+// list so that the fields they document/schema-ify can never drift apart.
+func flattenFieldSpecs(rootPath string, fields FieldSpecs) FieldSpecs {
+	flattened := FieldSpecs{}
+	var walk func(path string, f FieldSpecs)
+	walk = func(path string, f FieldSpecs) {
+		for _, v := range f {
+			newV := v
+			if len(path) > 0 {
+				newV.Name = path + newV.Name
+			}
+			flattened = append(flattened, newV)
+			if len(v.Children) > 0 {
+				newPath := path + v.Name
+				if newV.IsArray {
+					newPath = newPath + "[]"
+				} else if newV.IsMap {
+					newPath = newPath + ".<name>"
+				}
+				walk(newPath+".", v.Children)
+			}
+		}
+	}
+	walk(rootPath, fields)
+	return flattened
+}
+
+// resolveFieldDefault returns the default value for a (possibly flattened,
+// full-path) FieldSpec: its own Default if set, otherwise whatever sits at
+// its path in the full config example. It errors if neither is present, the
+// same invariant AsMarkdown has always enforced over every field it renders.
+func resolveFieldDefault(v FieldSpec, gConf *gabs.Container) (interface{}, error) {
+	defaultValue := v.Default
+	if defaultValue == nil {
+		defaultValue = gConf.Path(v.Name).Data()
+	}
+	if defaultValue == nil {
+		return nil, fmt.Errorf("field '%v' not found in config example and no default value was provided in the spec", v.Name)
+	}
+	return defaultValue, nil
+}
+
 func createOrderedConfig(t Type, rawExample interface{}, filter FieldFilter) (*yaml.Node, error) {
 	rawConfig := iClone(rawExample)
 	if err := SanitiseComponentConfig(t, rawConfig, filter); err != nil {
@@ -349,11 +403,15 @@ func genExampleConfigs(t Type, nest bool, fullConfigExample interface{}) (string
 	return string(commonConfigBytes), string(advancedConfigBytes), nil
 }
 
-// AsMarkdown renders the spec of a component, along with a full configuration
-// example, into a markdown document.
-func (c *ComponentSpec) AsMarkdown(nest bool, fullConfigExample interface{}) ([]byte, error) {
+// buildComponentContext gathers everything a component template needs to
+// render docs for c: front-matter style metadata, the common/advanced config
+// examples, and a flattened, template-ready fieldContext per field. It's the
+// single place that walks flattenFieldSpecs and resolves field defaults for
+// rendering, so every Renderer implementation documents the exact same
+// fields in the exact same way.
+func buildComponentContext(c *ComponentSpec, nest bool, fullConfigExample interface{}) (componentContext, error) {
 	if strings.Contains(c.Summary, "\n\n") {
-		return nil, fmt.Errorf("%v component '%v' has a summary containing empty lines", c.Type, c.Name)
+		return componentContext{}, fmt.Errorf("%v component '%v' has a summary containing empty lines", c.Type, c.Name)
 	}
 
 	ctx := componentContext{
@@ -377,7 +435,7 @@ func (c *ComponentSpec) AsMarkdown(nest bool, fullConfigExample interface{}) ([]
 
 	var err error
 	if ctx.CommonConfig, ctx.AdvancedConfig, err = genExampleConfigs(c.Type, nest, fullConfigExample); err != nil {
-		return nil, err
+		return componentContext{}, err
 	}
 
 	if len(c.Description) > 0 && c.Description[0] == '\n' {
@@ -387,33 +445,8 @@ func (c *ComponentSpec) AsMarkdown(nest bool, fullConfigExample interface{}) ([]
 		ctx.Footnotes = c.Footnotes[1:]
 	}
 
-	flattenedFields := FieldSpecs{}
-	var walkFields func(path string, f FieldSpecs)
-	walkFields = func(path string, f FieldSpecs) {
-		for _, v := range f {
-			newV := v
-			if len(path) > 0 {
-				newV.Name = path + newV.Name
-			}
-			flattenedFields = append(flattenedFields, newV)
-			if len(v.Children) > 0 {
-				newPath := path + v.Name
-				if newV.IsArray {
-					newPath = newPath + "[]"
-				} else if newV.IsMap {
-					newPath = newPath + ".<name>"
-				}
-				walkFields(newPath+".", v.Children)
-			}
-		}
-	}
-	rootPath := ""
-	if c.Config.IsArray {
-		rootPath = "[]."
-	} else if c.Config.IsMap {
-		rootPath = "<name>."
-	}
-	walkFields(rootPath, c.Config.Children)
+	rootPath := componentRootPath(c.Config)
+	flattenedFields := flattenFieldSpecs(rootPath, c.Config.Children)
 
 	gConf := gabs.Wrap(fullConfigExample).S(c.Name)
 	for _, v := range flattenedFields {
@@ -421,12 +454,9 @@ func (c *ComponentSpec) AsMarkdown(nest bool, fullConfigExample interface{}) ([]
 			continue
 		}
 
-		defaultValue := v.Default
-		if defaultValue == nil {
-			defaultValue = gConf.Path(v.Name).Data()
-		}
-		if defaultValue == nil {
-			return nil, fmt.Errorf("field '%v' not found in config example and no default value was provided in the spec", v.Name)
+		defaultValue, err := resolveFieldDefault(v, gConf)
+		if err != nil {
+			return componentContext{}, err
 		}
 
 		defaultValueStr := gabs.Wrap(defaultValue).String()
@@ -460,7 +490,7 @@ func (c *ComponentSpec) AsMarkdown(nest bool, fullConfigExample interface{}) ([]
 					exampleName: example,
 				})
 				if err != nil {
-					return nil, err
+					return componentContext{}, err
 				}
 				examples = append(examples, string(exampleBytes))
 			}
@@ -490,8 +520,13 @@ func (c *ComponentSpec) AsMarkdown(nest bool, fullConfigExample interface{}) ([]
 		ctx.Fields = append(ctx.Fields, fieldCtx)
 	}
 
-	var buf bytes.Buffer
-	err = template.Must(template.New("component").Parse(componentTemplate)).Execute(&buf, ctx)
+	return ctx, nil
+}
 
-	return buf.Bytes(), err
+// AsMarkdown renders the spec of a component, along with a full configuration
+// example, into a Docusaurus-flavoured MDX document. It's a thin wrapper
+// around DocusaurusRenderer kept for callers that rendered docs before
+// Renderer existed.
+func (c *ComponentSpec) AsMarkdown(nest bool, fullConfigExample interface{}) ([]byte, error) {
+	return DocusaurusRenderer{}.RenderComponent(c, RenderOptions{Nest: nest, FullConfigExample: fullConfigExample})
 }
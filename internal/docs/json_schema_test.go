@@ -0,0 +1,295 @@
+package docs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFieldSpecAsJSONSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		field    FieldSpec
+		expected map[string]interface{}
+	}{
+		{
+			name: "plain string field",
+			field: FieldSpec{
+				Name:        "foo",
+				Type:        "string",
+				Description: "a foo value",
+			},
+			expected: map[string]interface{}{
+				"type":        "string",
+				"description": "a foo value",
+			},
+		},
+		{
+			name: "int field with default",
+			field: FieldSpec{
+				Name:    "retries",
+				Type:    "int",
+				Default: 3,
+			},
+			expected: map[string]interface{}{
+				"type":    "integer",
+				"default": 3,
+			},
+		},
+		{
+			name: "array of strings",
+			field: FieldSpec{
+				Name:    "urls",
+				Type:    "string",
+				IsArray: true,
+			},
+			expected: map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "string",
+				},
+			},
+		},
+		{
+			name: "map of strings",
+			field: FieldSpec{
+				Name:  "headers",
+				IsMap: true,
+				Children: FieldSpecs{
+					{Type: "string"},
+				},
+			},
+			expected: map[string]interface{}{
+				"type": "object",
+				"additionalProperties": map[string]interface{}{
+					"type": "string",
+				},
+			},
+		},
+		{
+			name: "nested object",
+			field: FieldSpec{
+				Name: "tls",
+				Children: FieldSpecs{
+					{Name: "enabled", Type: "bool"},
+				},
+			},
+			expected: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"enabled": map[string]interface{}{"type": "boolean"},
+				},
+			},
+		},
+		{
+			name: "annotated options take priority over options",
+			field: FieldSpec{
+				Name:             "codec",
+				Type:             "string",
+				Options:          []string{"a", "b"},
+				AnnotatedOptions: [][2]string{{"a", "summary a"}, {"b", "summary b"}},
+			},
+			expected: map[string]interface{}{
+				"type": "string",
+				"enum": []string{"a", "b"},
+			},
+		},
+		{
+			name: "deprecated advanced interpolated field with version",
+			field: FieldSpec{
+				Name:         "old_field",
+				Type:         "string",
+				Deprecated:   true,
+				Advanced:     true,
+				Interpolated: true,
+				Version:      "3.42.0",
+			},
+			expected: map[string]interface{}{
+				"type":                   "string",
+				"deprecated":             true,
+				"x-benthos-advanced":     true,
+				"x-benthos-interpolated": true,
+				"x-benthos-version":      "3.42.0",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := test.field.AsJSONSchema()
+			if !reflect.DeepEqual(test.expected, actual) {
+				t.Errorf("wrong schema for %v:\nexpected: %#v\nactual:   %#v", test.name, test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestComponentSpecAsJSONSchemaRootKind(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       FieldSpec
+		example      interface{}
+		expectedType string
+	}{
+		{
+			name: "plain object root",
+			config: FieldSpec{
+				Children: FieldSpecs{
+					{Name: "url", Type: "string", Default: "http://example.com"},
+				},
+			},
+			example:      map[string]interface{}{"foo": map[string]interface{}{"url": "http://example.com"}},
+			expectedType: "object",
+		},
+		{
+			name: "array root",
+			config: FieldSpec{
+				IsArray: true,
+				Children: FieldSpecs{
+					{Name: "url", Type: "string", Default: "http://example.com"},
+				},
+			},
+			example:      map[string]interface{}{"foo": []interface{}{}},
+			expectedType: "array",
+		},
+		{
+			name: "map root",
+			config: FieldSpec{
+				IsMap: true,
+				Children: FieldSpecs{
+					{Name: "url", Type: "string", Default: "http://example.com"},
+				},
+			},
+			example:      map[string]interface{}{"foo": map[string]interface{}{}},
+			expectedType: "object",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec := ComponentSpec{Name: "foo", Type: TypeInput, Config: test.config}
+			schema, err := spec.AsJSONSchema(test.example)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if schema["type"] != test.expectedType {
+				t.Errorf("expected root type %q, got %q", test.expectedType, schema["type"])
+			}
+			if test.config.IsArray {
+				items, ok := schema["items"].(map[string]interface{})
+				if !ok {
+					t.Fatalf("expected items to be an object schema, got %#v", schema["items"])
+				}
+				if _, ok := items["properties"]; !ok {
+					t.Errorf("expected items schema to carry properties")
+				}
+			}
+			if test.config.IsMap {
+				if _, ok := schema["additionalProperties"].(map[string]interface{}); !ok {
+					t.Errorf("expected additionalProperties to be an object schema, got %#v", schema["additionalProperties"])
+				}
+			}
+		})
+	}
+}
+
+func TestComponentSpecAsJSONSchemaNestedDefaults(t *testing.T) {
+	spec := ComponentSpec{
+		Name: "http_client",
+		Type: TypeOutput,
+		Config: FieldSpec{
+			Children: FieldSpecs{
+				{
+					Name: "tls",
+					Children: FieldSpecs{
+						{Name: "enabled", Type: "bool"},
+					},
+				},
+			},
+		},
+	}
+
+	example := map[string]interface{}{
+		"http_client": map[string]interface{}{
+			"tls": map[string]interface{}{
+				"enabled": false,
+			},
+		},
+	}
+
+	schema, err := spec.AsJSONSchema(example)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	props, _ := schema["properties"].(map[string]interface{})
+	tlsSchema, _ := props["tls"].(map[string]interface{})
+	tlsProps, _ := tlsSchema["properties"].(map[string]interface{})
+	enabledSchema, _ := tlsProps["enabled"].(map[string]interface{})
+
+	if enabledSchema == nil {
+		t.Fatalf("expected a schema for tls.enabled, got %#v", tlsSchema)
+	}
+	if enabledSchema["default"] != false {
+		t.Errorf("expected tls.enabled default to be backfilled from the config example, got %#v", enabledSchema["default"])
+	}
+}
+
+func TestComponentSpecAsJSONSchemaMissingDefaultErrors(t *testing.T) {
+	spec := ComponentSpec{
+		Name: "http_client",
+		Type: TypeOutput,
+		Config: FieldSpec{
+			Children: FieldSpecs{
+				{Name: "url", Type: "string"},
+			},
+		},
+	}
+
+	if _, err := spec.AsJSONSchema(map[string]interface{}{"http_client": map[string]interface{}{}}); err == nil {
+		t.Error("expected an error for a field with neither a Default nor a value in the config example")
+	}
+}
+
+func TestBuildOneOfSchema(t *testing.T) {
+	specs := []ComponentSpec{
+		{
+			Name: "http_client",
+			Type: TypeInput,
+			Config: FieldSpec{
+				Children: FieldSpecs{{Name: "url", Type: "string", Default: "http://example.com"}},
+			},
+		},
+		{
+			Name: "kafka",
+			Type: TypeInput,
+			Config: FieldSpec{
+				Children: FieldSpecs{{Name: "addresses", Type: "string", IsArray: true, Default: []interface{}{}}},
+			},
+		},
+		{
+			Name: "broker",
+			Type: TypeOutput,
+			Config: FieldSpec{
+				Children: FieldSpecs{{Name: "pattern", Type: "string", Default: "fan_out"}},
+			},
+		},
+	}
+	examples := map[string]interface{}{
+		"http_client": map[string]interface{}{"http_client": map[string]interface{}{}},
+		"kafka":       map[string]interface{}{"kafka": map[string]interface{}{}},
+		"broker":      map[string]interface{}{"broker": map[string]interface{}{}},
+	}
+
+	schema, err := BuildOneOfSchema(TypeInput, specs, examples)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	oneOf, ok := schema["oneOf"].([]interface{})
+	if !ok {
+		t.Fatalf("expected oneOf to be a slice, got %#v", schema["oneOf"])
+	}
+	if len(oneOf) != 2 {
+		t.Fatalf("expected only the two input components, got %v entries", len(oneOf))
+	}
+}
@@ -0,0 +1,203 @@
+package docs
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+	"text/template"
+)
+
+// RenderOptions configures how a Renderer documents a component.
+type RenderOptions struct {
+	// Nest indicates the config example should be nested under the
+	// component's type (e.g. `input:\n  foo: {}` rather than just `foo: {}`).
+	Nest bool
+
+	// FullConfigExample is a complete, valid config for the component type
+	// the spec belongs to, used to fill in default values that aren't set
+	// explicitly on a FieldSpec.
+	FullConfigExample interface{}
+}
+
+// Renderer produces documentation for a ComponentSpec in some output format.
+// Implementations share buildComponentContext so they all document the same
+// fields, in the same order, with the same resolved defaults - only the
+// presentation differs.
+type Renderer interface {
+	RenderComponent(c *ComponentSpec, opts RenderOptions) ([]byte, error)
+}
+
+// DocusaurusRenderer renders Docusaurus-flavoured MDX, the format used by the
+// website docs (front matter, `Tabs`/`TabItem` imports, `:::warning`
+// admonitions).
+type DocusaurusRenderer struct{}
+
+// RenderComponent implements Renderer.
+func (DocusaurusRenderer) RenderComponent(c *ComponentSpec, opts RenderOptions) ([]byte, error) {
+	ctx, err := buildComponentContext(c, opts.Nest, opts.FullConfigExample)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := template.Must(template.New("component").Parse(componentTemplate)).Execute(&buf, ctx); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarkdownRenderer renders plain CommonMark with no Docusaurus-specific
+// syntax, suitable for embedding in a GitHub README: no front matter, no
+// `Tabs` imports, admonitions become blockquotes.
+type MarkdownRenderer struct{}
+
+var plainMarkdownTemplate = `{{define "field_docs" -}}
+## Fields
+
+{{range $i, $field := .Fields -}}
+### ` + "`{{$field.Name}}`" + `
+
+{{$field.Description}}
+{{if $field.Interpolated -}}
+This field supports interpolation functions.
+{{end}}
+
+Type: ` + "`{{$field.Type}}`" + `
+{{if gt (len $field.Default) 0}}Default: ` + "`{{$field.Default}}`" + `
+{{end -}}
+{{if gt (len $field.Version) 0}}Requires version {{$field.Version}} or newer
+{{end -}}
+{{if gt (len $field.AnnotatedOptions) 0}}
+| Option | Summary |
+|---|---|
+{{range $j, $option := $field.AnnotatedOptions -}}` + "| `" + `{{index $option 0}}` + "` |" + ` {{index $option 1}} |
+{{end}}
+{{else if gt (len $field.Options) 0}}Options: {{range $j, $option := $field.Options -}}
+{{if ne $j 0}}, {{end}}` + "`" + `{{$option}}` + "`" + `{{end}}.
+{{end}}
+{{if gt (len $field.Examples) 0 -}}
+` + "```yaml" + `
+# Examples
+
+{{range $j, $example := $field.Examples -}}
+{{if ne $j 0}}
+{{end}}{{$example}}{{end -}}
+` + "```" + `
+
+{{end -}}
+{{end -}}
+{{end -}}
+
+# {{.Name}}
+
+{{if eq .Status "beta" -}}
+> **Beta:** this component is mostly stable but breaking changes could still be made outside of major version releases if a fundamental problem with the component is found.
+{{end -}}
+{{if eq .Status "experimental" -}}
+> **Experimental:** this component is experimental and therefore subject to change or removal outside of major version releases.
+{{end -}}
+{{if eq .Status "deprecated" -}}
+> **Deprecated:** this component is deprecated and will be removed in the next major version release. Please consider moving onto alternative components.
+{{end -}}
+
+{{if gt (len .Summary) 0 -}}
+{{.Summary}}
+{{end -}}{{if gt (len .Version) 0}}
+Introduced in version {{.Version}}.
+{{end}}
+` + "```yaml" + `
+# Config fields, showing default values
+{{.AdvancedConfig -}}
+` + "```" + `
+{{if gt (len .Description) 0}}
+{{.Description}}
+{{end}}
+{{template "field_docs" . -}}
+
+{{if gt (len .Examples) 0 -}}
+## Examples
+
+{{range $i, $example := .Examples -}}
+### {{$example.Title}}
+
+{{if gt (len $example.Summary) 0 -}}
+{{$example.Summary}}
+{{end}}
+{{if gt (len $example.Config) 0 -}}
+` + "```yaml" + `{{$example.Config}}` + "```" + `
+{{end}}
+{{end -}}
+{{end -}}
+
+{{if gt (len .Footnotes) 0 -}}
+{{.Footnotes}}
+{{end}}
+`
+
+// RenderComponent implements Renderer.
+func (MarkdownRenderer) RenderComponent(c *ComponentSpec, opts RenderOptions) ([]byte, error) {
+	ctx, err := buildComponentContext(c, opts.Nest, opts.FullConfigExample)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := template.Must(template.New("component").Parse(plainMarkdownTemplate)).Execute(&buf, ctx); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// HTMLRenderer renders a self-contained HTML fragment with anchored fields
+// and collapsible common/advanced config sections, for embedding in
+// generated reference sites that don't run Docusaurus.
+type HTMLRenderer struct{}
+
+// RenderComponent implements Renderer.
+func (HTMLRenderer) RenderComponent(c *ComponentSpec, opts RenderOptions) ([]byte, error) {
+	ctx, err := buildComponentContext(c, opts.Nest, opts.FullConfigExample)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<article id=%q>\n", html.EscapeString(ctx.Name))
+	fmt.Fprintf(&buf, "<h1>%s</h1>\n", html.EscapeString(ctx.Name))
+	if len(ctx.Summary) > 0 {
+		fmt.Fprintf(&buf, "<p>%s</p>\n", html.EscapeString(ctx.Summary))
+	}
+
+	buf.WriteString("<details open>\n<summary>Common config</summary>\n")
+	fmt.Fprintf(&buf, "<pre><code>%s</code></pre>\n", html.EscapeString(ctx.CommonConfig))
+	buf.WriteString("</details>\n")
+	if ctx.CommonConfig != ctx.AdvancedConfig {
+		buf.WriteString("<details>\n<summary>Advanced config</summary>\n")
+		fmt.Fprintf(&buf, "<pre><code>%s</code></pre>\n", html.EscapeString(ctx.AdvancedConfig))
+		buf.WriteString("</details>\n")
+	}
+
+	if len(ctx.Description) > 0 {
+		fmt.Fprintf(&buf, "<p>%s</p>\n", html.EscapeString(ctx.Description))
+	}
+
+	if len(ctx.Fields) > 0 {
+		buf.WriteString("<h2>Fields</h2>\n")
+		for _, field := range ctx.Fields {
+			anchor := strings.NewReplacer(".", "-", "[", "", "]", "").Replace(field.Name)
+			fmt.Fprintf(&buf, "<h3 id=%q><code>%s</code></h3>\n", html.EscapeString(anchor), html.EscapeString(field.Name))
+			fmt.Fprintf(&buf, "<p>%s</p>\n", html.EscapeString(field.Description))
+			fmt.Fprintf(&buf, "<p>Type: <code>%s</code></p>\n", html.EscapeString(field.Type))
+			if len(field.Default) > 0 {
+				fmt.Fprintf(&buf, "<p>Default: <code>%s</code></p>\n", html.EscapeString(field.Default))
+			}
+		}
+	}
+
+	if len(ctx.Footnotes) > 0 {
+		fmt.Fprintf(&buf, "<p>%s</p>\n", html.EscapeString(ctx.Footnotes))
+	}
+	buf.WriteString("</article>\n")
+
+	return buf.Bytes(), nil
+}